@@ -0,0 +1,253 @@
+// Package pointer implements RFC 6901 JSON Pointers: parsing a pointer
+// string into reference tokens and using those tokens to get, set, or
+// delete a single location within a parsed JSON document (interface{}
+// trees of map[string]interface{}, []interface{} and scalars, as produced
+// by encoding/json).
+package pointer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is returned when a pointer addresses a location that does
+// not exist and the operation was not told to create it.
+var ErrNotFound = errors.New("json pointer: not found")
+
+// Pointer is a parsed RFC 6901 JSON Pointer: a sequence of unescaped
+// reference tokens.
+type Pointer []string
+
+// Parse parses an RFC 6901 JSON Pointer string ("" or "/a/b/0") into its
+// reference tokens, unescaping "~1" to "/" and "~0" to "~".
+func Parse(s string) (Pointer, error) {
+	if s == "" {
+		return Pointer{}, nil
+	}
+	if !strings.HasPrefix(s, "/") {
+		return nil, errors.New("json pointer: must be empty or start with '/'")
+	}
+
+	parts := strings.Split(s[1:], "/")
+	tokens := make(Pointer, len(parts))
+	for i, p := range parts {
+		tokens[i] = unescape(p)
+	}
+	return tokens, nil
+}
+
+func unescape(tok string) string {
+	tok = strings.ReplaceAll(tok, "~1", "/")
+	tok = strings.ReplaceAll(tok, "~0", "~")
+	return tok
+}
+
+// Get returns the value addressed by ptr within doc, or ErrNotFound if any
+// segment along the way does not exist.
+func Get(doc interface{}, ptr Pointer) (interface{}, error) {
+	node := doc
+	for _, tok := range ptr {
+		child, err := step(node, tok)
+		if err != nil {
+			return nil, err
+		}
+		node = child
+	}
+	return node, nil
+}
+
+func step(node interface{}, tok string) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		child, ok := v[tok]
+		if !ok {
+			return nil, ErrNotFound
+		}
+		return child, nil
+	case []interface{}:
+		idx, ok := arrayIndex(tok, len(v))
+		if !ok {
+			return nil, ErrNotFound
+		}
+		return v[idx], nil
+	default:
+		return nil, ErrNotFound
+	}
+}
+
+// arrayIndex parses tok as an in-bounds array index, for a slice with
+// length.
+func arrayIndex(tok string, length int) (int, bool) {
+	if tok == "-" {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(tok)
+	if err != nil || idx < 0 || idx >= length {
+		return 0, false
+	}
+	return idx, true
+}
+
+// isArrayToken reports whether tok looks like an array index ("-" for
+// append, or a non-negative integer), used to decide what type of
+// container to create when force-creating missing intermediates.
+func isArrayToken(tok string) bool {
+	if tok == "-" {
+		return true
+	}
+	if tok == "" {
+		return false
+	}
+	for _, r := range tok {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func newContainer(nextTok string) interface{} {
+	if isArrayToken(nextTok) {
+		return []interface{}{}
+	}
+	return map[string]interface{}{}
+}
+
+// Set returns doc with value written at ptr. When force is true, missing
+// intermediate objects/arrays are created along the way (the container
+// type is guessed from the following token); otherwise a missing segment
+// is reported as ErrNotFound. A final "-" token appends to the addressed
+// array, per RFC 6901.
+func Set(doc interface{}, ptr Pointer, value interface{}, force bool) (interface{}, error) {
+	if len(ptr) == 0 {
+		return value, nil
+	}
+	return setAt(doc, ptr, value, force)
+}
+
+func setAt(node interface{}, ptr Pointer, value interface{}, force bool) (interface{}, error) {
+	tok, rest := ptr[0], ptr[1:]
+
+	if node == nil {
+		if !force {
+			return nil, ErrNotFound
+		}
+		node = newContainer(tok)
+	}
+
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if len(rest) == 0 {
+			v[tok] = value
+			return v, nil
+		}
+		child, ok := v[tok]
+		if !ok {
+			if !force {
+				return nil, ErrNotFound
+			}
+			child = newContainer(rest[0])
+		}
+		updated, err := setAt(child, rest, value, force)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = updated
+		return v, nil
+
+	case []interface{}:
+		if tok == "-" {
+			if len(rest) != 0 {
+				return nil, errors.New(`json pointer: "-" may only be used as the final token`)
+			}
+			return append(v, value), nil
+		}
+
+		idx, err := strconv.Atoi(tok)
+		if err != nil || idx < 0 || idx > len(v) {
+			return nil, fmt.Errorf("json pointer: invalid array index %q", tok)
+		}
+
+		if idx == len(v) {
+			if !force {
+				return nil, ErrNotFound
+			}
+			if len(rest) == 0 {
+				return append(v, value), nil
+			}
+			updated, err := setAt(newContainer(rest[0]), rest, value, force)
+			if err != nil {
+				return nil, err
+			}
+			return append(v, updated), nil
+		}
+
+		if len(rest) == 0 {
+			v[idx] = value
+			return v, nil
+		}
+		updated, err := setAt(v[idx], rest, value, force)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = updated
+		return v, nil
+
+	default:
+		return nil, fmt.Errorf("json pointer: cannot descend into %T", node)
+	}
+}
+
+// Delete returns doc with the member or array element addressed by ptr
+// removed. Deleting the document root (an empty pointer) is an error.
+func Delete(doc interface{}, ptr Pointer) (interface{}, error) {
+	if len(ptr) == 0 {
+		return nil, errors.New("json pointer: cannot delete the document root")
+	}
+	return deleteAt(doc, ptr)
+}
+
+func deleteAt(node interface{}, ptr Pointer) (interface{}, error) {
+	tok, rest := ptr[0], ptr[1:]
+
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if len(rest) == 0 {
+			if _, ok := v[tok]; !ok {
+				return nil, ErrNotFound
+			}
+			delete(v, tok)
+			return v, nil
+		}
+		child, ok := v[tok]
+		if !ok {
+			return nil, ErrNotFound
+		}
+		updated, err := deleteAt(child, rest)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = updated
+		return v, nil
+
+	case []interface{}:
+		idx, ok := arrayIndex(tok, len(v))
+		if !ok {
+			return nil, ErrNotFound
+		}
+		if len(rest) == 0 {
+			return append(v[:idx], v[idx+1:]...), nil
+		}
+		updated, err := deleteAt(v[idx], rest)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = updated
+		return v, nil
+
+	default:
+		return nil, ErrNotFound
+	}
+}
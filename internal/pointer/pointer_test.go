@@ -0,0 +1,184 @@
+package pointer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func mustParseJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+	var data interface{}
+	if err := json.Unmarshal([]byte(s), &data); err != nil {
+		t.Fatalf("failed to parse test JSON: %v", err)
+	}
+	return data
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Pointer
+		wantErr bool
+	}{
+		{"empty is root", "", Pointer{}, false},
+		{"simple path", "/a/b", Pointer{"a", "b"}, false},
+		{"escaped tilde and slash", "/a~0b/c~1d", Pointer{"a~b", "c/d"}, false},
+		{"array index", "/a/0", Pointer{"a", "0"}, false},
+		{"missing leading slash", "a/b", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	doc := mustParseJSON(t, `{"users": [{"name": "Alice"}, {"name": "Bob"}], "count": 2}`)
+
+	tests := []struct {
+		name string
+		ptr  string
+		want interface{}
+	}{
+		{"field", "/count", 2.0},
+		{"array element field", "/users/1/name", "Bob"},
+		{"root", "", doc},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ptr, err := Parse(tt.ptr)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			got, err := Get(doc, ptr)
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	doc := mustParseJSON(t, `{"a": 1}`)
+	ptr, _ := Parse("/b/c")
+	_, err := Get(doc, ptr)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSetExisting(t *testing.T) {
+	doc := mustParseJSON(t, `{"users": [{"name": "Alice"}]}`)
+	ptr, _ := Parse("/users/0/name")
+
+	updated, err := Set(doc, ptr, "Charlie", false)
+	if err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	got, err := Get(updated, ptr)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != "Charlie" {
+		t.Errorf("got %v, want Charlie", got)
+	}
+}
+
+func TestSetMissingWithoutForce(t *testing.T) {
+	doc := mustParseJSON(t, `{}`)
+	ptr, _ := Parse("/a/b")
+
+	if _, err := Set(doc, ptr, 1, false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound without --force, got %v", err)
+	}
+}
+
+func TestSetForceCreatesIntermediates(t *testing.T) {
+	doc := mustParseJSON(t, `{}`)
+	ptr, _ := Parse("/a/b/0/c")
+
+	updated, err := Set(doc, ptr, "v", true)
+	if err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	got, err := Get(updated, ptr)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != "v" {
+		t.Errorf("got %v, want v", got)
+	}
+}
+
+func TestSetAppend(t *testing.T) {
+	doc := mustParseJSON(t, `{"items": [1, 2]}`)
+	ptr, _ := Parse("/items/-")
+
+	updated, err := Set(doc, ptr, 3.0, false)
+	if err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	items := updated.(map[string]interface{})["items"].([]interface{})
+	want := []interface{}{1.0, 2.0, 3.0}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("got %#v, want %#v", items, want)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	doc := mustParseJSON(t, `{"users": [{"name": "Alice"}, {"name": "Bob"}]}`)
+	ptr, _ := Parse("/users/0")
+
+	updated, err := Delete(doc, ptr)
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	users := updated.(map[string]interface{})["users"].([]interface{})
+	if len(users) != 1 {
+		t.Fatalf("expected 1 user remaining, got %d", len(users))
+	}
+	if users[0].(map[string]interface{})["name"] != "Bob" {
+		t.Errorf("expected Bob to remain, got %#v", users[0])
+	}
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	doc := mustParseJSON(t, `{"a": 1}`)
+	ptr, _ := Parse("/b")
+
+	if _, err := Delete(doc, ptr); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDeleteRoot(t *testing.T) {
+	doc := mustParseJSON(t, `{"a": 1}`)
+	if _, err := Delete(doc, Pointer{}); err == nil {
+		t.Errorf("expected an error deleting the document root")
+	}
+}
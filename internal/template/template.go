@@ -0,0 +1,239 @@
+// Package template implements a small subset of the Kubernetes client-go
+// jsonpath template engine: literal text interspersed with `{ $.some.path }`
+// expressions and `{range ...}{end}` loops, evaluated with the ojg jp
+// engine instead of client-go's own JSONPath implementation.
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ohler55/ojg/jp"
+)
+
+// Template is a parsed template ready to be executed against JSON data.
+type Template struct {
+	nodes []node
+}
+
+// Parse tokenizes and parses a template string, resolving every
+// `{ expr }` and `{range expr}...{end}` block into a JSONPath expression via
+// jp.ParseString.
+func Parse(input string) (*Template, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, pos, err := parseNodes(tokens, 0)
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(tokens) {
+		return nil, fmt.Errorf("unexpected {end} without a matching {range}")
+	}
+
+	return &Template{nodes: nodes}, nil
+}
+
+// Execute evaluates the template against data, substituting each
+// expression's result into the output. If allowMissingKeys is false, a
+// path with no match is an error; otherwise it renders as empty.
+func (t *Template) Execute(data interface{}, allowMissingKeys bool) (string, error) {
+	var buf bytes.Buffer
+	if err := executeNodes(t.nodes, &buf, data, allowMissingKeys); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// node is one piece of a parsed template: literal text, a single
+// expression, or a range loop.
+type node interface {
+	execute(buf *bytes.Buffer, data interface{}, allowMissingKeys bool) error
+}
+
+type textNode string
+
+func (n textNode) execute(buf *bytes.Buffer, _ interface{}, _ bool) error {
+	buf.WriteString(string(n))
+	return nil
+}
+
+type exprNode struct {
+	raw  string
+	expr jp.Expr
+}
+
+func (n exprNode) execute(buf *bytes.Buffer, data interface{}, allowMissingKeys bool) error {
+	results := n.expr.Get(data)
+	if len(results) == 0 {
+		if allowMissingKeys {
+			return nil
+		}
+		return fmt.Errorf("%s is not found", n.raw)
+	}
+	for _, r := range results {
+		buf.WriteString(formatValue(r))
+	}
+	return nil
+}
+
+type rangeNode struct {
+	raw  string
+	expr jp.Expr
+	body []node
+}
+
+func (n rangeNode) execute(buf *bytes.Buffer, data interface{}, allowMissingKeys bool) error {
+	items := n.expr.Get(data)
+	if len(items) == 0 {
+		if allowMissingKeys {
+			return nil
+		}
+		return fmt.Errorf("%s is not found", n.raw)
+	}
+
+	for _, item := range items {
+		if err := executeNodes(n.body, buf, item, allowMissingKeys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func executeNodes(nodes []node, buf *bytes.Buffer, data interface{}, allowMissingKeys bool) error {
+	for _, n := range nodes {
+		if err := n.execute(buf, data, allowMissingKeys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatValue renders a JSONPath match the way kubectl's jsonpath printer
+// does: scalars as their bare value, arrays and objects as JSON.
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case nil:
+		return ""
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(b)
+	}
+}
+
+// token is one lexical unit produced by tokenize: literal text, a quoted
+// string literal inside `{...}`, a bare expression, the start of a range
+// loop, or its matching end.
+type token struct {
+	kind string // "text", "literal", "expr", "range", "end"
+	text string
+}
+
+// tokenize splits a template string into literal text and `{...}` blocks.
+// A `{...}` block whose body starts with a quote, e.g. {"\n"}, is a quoted
+// string literal like kubectl's jsonpath printer supports, rather than a
+// JSONPath expression.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(input); {
+		open := strings.IndexByte(input[i:], '{')
+		if open == -1 {
+			tokens = append(tokens, token{"text", input[i:]})
+			break
+		}
+		if open > 0 {
+			tokens = append(tokens, token{"text", input[i : i+open]})
+		}
+
+		start := i + open
+		closeRel := strings.IndexByte(input[start:], '}')
+		if closeRel == -1 {
+			return nil, fmt.Errorf("unterminated expression starting at %q", input[start:])
+		}
+
+		body := strings.TrimSpace(input[start+1 : start+closeRel])
+		switch {
+		case body == "end":
+			tokens = append(tokens, token{"end", ""})
+		case body == "range":
+			return nil, fmt.Errorf("range requires a JSONPath expression, e.g. {range $.items[*]}")
+		case strings.HasPrefix(body, "range "):
+			tokens = append(tokens, token{"range", strings.TrimSpace(strings.TrimPrefix(body, "range "))})
+		case strings.HasPrefix(body, `"`):
+			tokens = append(tokens, token{"literal", body})
+		default:
+			tokens = append(tokens, token{"expr", body})
+		}
+
+		i = start + closeRel + 1
+	}
+
+	return tokens, nil
+}
+
+// parseNodes consumes tokens starting at pos and returns the resulting node
+// list along with the index of the token that stopped it: end-of-input at
+// the top level, or the matching {end} for a range body.
+func parseNodes(tokens []token, pos int) ([]node, int, error) {
+	var nodes []node
+
+	for pos < len(tokens) {
+		tok := tokens[pos]
+		switch tok.kind {
+		case "text":
+			nodes = append(nodes, textNode(tok.text))
+			pos++
+
+		case "literal":
+			unquoted, err := strconv.Unquote(tok.text)
+			if err != nil {
+				return nil, pos, fmt.Errorf("invalid literal %q: %v", tok.text, err)
+			}
+			nodes = append(nodes, textNode(unquoted))
+			pos++
+
+		case "expr":
+			expr, err := jp.ParseString(tok.text)
+			if err != nil {
+				return nil, pos, fmt.Errorf("invalid expression %q: %v", tok.text, err)
+			}
+			nodes = append(nodes, exprNode{raw: tok.text, expr: expr})
+			pos++
+
+		case "range":
+			expr, err := jp.ParseString(tok.text)
+			if err != nil {
+				return nil, pos, fmt.Errorf("invalid range expression %q: %v", tok.text, err)
+			}
+			body, next, err := parseNodes(tokens, pos+1)
+			if err != nil {
+				return nil, pos, err
+			}
+			if next >= len(tokens) || tokens[next].kind != "end" {
+				return nil, pos, fmt.Errorf("{range %s} is missing its {end}", tok.text)
+			}
+			nodes = append(nodes, rangeNode{raw: tok.text, expr: expr, body: body})
+			pos = next + 1
+
+		case "end":
+			return nodes, pos, nil
+		}
+	}
+
+	return nodes, pos, nil
+}
@@ -0,0 +1,134 @@
+package template
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func mustParseJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+	var data interface{}
+	if err := json.Unmarshal([]byte(s), &data); err != nil {
+		t.Fatalf("failed to parse test JSON: %v", err)
+	}
+	return data
+}
+
+func TestExecuteLiteralAndExpr(t *testing.T) {
+	data := mustParseJSON(t, `{"name": "Alice", "age": 30}`)
+
+	tmpl, err := Parse("Name: {$.name}, Age: {$.age}\n")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	out, err := tmpl.Execute(data, false)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	want := "Name: Alice, Age: 30\n"
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestExecuteRange(t *testing.T) {
+	data := mustParseJSON(t, `{"users": [{"name": "Alice"}, {"name": "Bob"}]}`)
+
+	tmpl, err := Parse(`{range $.users[*]}{.name}` + "\n" + `{end}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	out, err := tmpl.Execute(data, false)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	want := "Alice\nBob\n"
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestExecuteQuotedLiteral(t *testing.T) {
+	data := mustParseJSON(t, `{"users": [{"name": "Alice"}, {"name": "Bob"}]}`)
+
+	tmpl, err := Parse(`{range $.users[*]}{.name}{"\n"}{end}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	out, err := tmpl.Execute(data, false)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	want := "Alice\nBob\n"
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestExecuteArrayAndObjectAsJSON(t *testing.T) {
+	data := mustParseJSON(t, `{"tags": ["a", "b"], "meta": {"k": "v"}}`)
+
+	tmpl, err := Parse("{$.tags} {$.meta}")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	out, err := tmpl.Execute(data, false)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	want := `["a","b"] {"k":"v"}`
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestExecuteMissingKey(t *testing.T) {
+	data := mustParseJSON(t, `{"name": "Alice"}`)
+
+	tmpl, err := Parse("{$.missing}")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if _, err := tmpl.Execute(data, false); err == nil {
+		t.Errorf("expected an error for a missing key without --allow-missing-keys")
+	}
+
+	out, err := tmpl.Execute(data, true)
+	if err != nil {
+		t.Fatalf("unexpected error with allowMissingKeys: %v", err)
+	}
+	if out != "" {
+		t.Errorf("expected empty output for missing key, got %q", out)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unterminated expression", "{$.name"},
+		{"range without expression", "{range}{end}"},
+		{"range missing end", "{range $.items[*]}{.name}"},
+		{"stray end", "{end}"},
+		{"invalid jsonpath", "{$.[}"},
+		{"invalid quoted literal", `{"\q"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.input); err == nil {
+				t.Errorf("expected a parse error for %q", tt.input)
+			}
+		})
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/ohler55/ojg/jp"
+
+	"github.com/berrydev-ai/query_json/internal/pointer"
+	"github.com/berrydev-ai/query_json/internal/template"
 )
 
 // Version information - set via ldflags during build
@@ -20,16 +23,46 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "test":
+			os.Exit(runTestCommand(os.Args[2:]))
+		case "get", "set", "delete":
+			os.Exit(runPointerCommand(os.Args[1], os.Args[2:]))
+		}
+	}
+
 	var query string
 	var pretty bool
 	var raw bool
 	var showVersion bool
+	var templateExpr string
+	var allowMissingKeys bool
+	var pointerExpr string
+	var bigNumbers bool
+	var ndjson bool
+	var filterMode bool
+	var flatten bool
 	flag.StringVar(&query, "query", "", "JSONPath query (e.g., $.root[0], $.users[*].name)")
 	flag.BoolVar(&pretty, "pretty", true, "Pretty print JSON output")
 	flag.BoolVar(&raw, "raw", false, "Output raw values (no JSON formatting for strings)")
 	flag.BoolVar(&showVersion, "version", false, "Show version information")
+	flag.StringVar(&templateExpr, "template", "", "Kubernetes-style JSONPath template, e.g. '{range $.items[*]}{.name}{\"\\n\"}{end}'")
+	flag.BoolVar(&allowMissingKeys, "allow-missing-keys", false, "Render missing --template paths as empty instead of failing")
+	flag.StringVar(&pointerExpr, "pointer", "", "RFC 6901 JSON Pointer (e.g. /users/0/name), mutually exclusive with --query")
+	flag.BoolVar(&bigNumbers, "big-numbers", false, "Preserve numeric precision using json.Number instead of float64 (only supports == and != in filters, not ordering operators)")
+	flag.BoolVar(&ndjson, "ndjson", false, "Treat the input as newline-delimited JSON, querying each record independently (inferred from .ndjson/.jsonl)")
+	flag.BoolVar(&filterMode, "filter", false, "With --ndjson, only emit records where the query yields a non-empty result")
+	flag.BoolVar(&flatten, "flatten", false, "With --ndjson, emit multi-value query results one per line instead of as a JSON array")
 	flag.Parse()
 
+	prettySet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "pretty" {
+			prettySet = true
+		}
+	})
+
 	if showVersion {
 		fmt.Printf("query_json version %s\n", version)
 		fmt.Printf("  commit: %s\n", commit)
@@ -45,69 +78,179 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s --query '$.users[0].name' ./examples/data.json\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --query '$.products[?(@.price > 100)]' ./examples/data.json\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --query '$.users[*].email' --raw ./examples/data.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --template '{range $.users[*]}{.name}{\"\\n\"}{end}' ./examples/data.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --pointer /users/0/name ./examples/data.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s test ./examples/tests\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s set --pointer /users/0/name --value '\"Bob\"' ./examples/data.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --query '$.error' --filter ./app.ndjson\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	if query != "" && pointerExpr != "" {
+		fmt.Fprintf(os.Stderr, "Error: --query and --pointer are mutually exclusive\n")
 		os.Exit(1)
 	}
 
 	filename := flag.Args()[0]
+	ndjson = ndjson || strings.HasSuffix(filename, ".ndjson") || strings.HasSuffix(filename, ".jsonl")
+	pretty = effectivePretty(pretty, prettySet, ndjson)
+
+	if templateExpr == "" && pointerExpr == "" {
+		if query == "" {
+			fmt.Fprintf(os.Stderr, "Error: --query parameter is required\n")
+			os.Exit(1)
+		}
+
+		// Validate JSONPath syntax
+		if err := validateJSONPath(query); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid JSONPath query: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if ndjson {
+		if templateExpr != "" || pointerExpr != "" {
+			fmt.Fprintf(os.Stderr, "Error: --ndjson only supports --query\n")
+			os.Exit(1)
+		}
+		if err := runNDJSONQuery(filename, query, pretty, raw, filterMode, flatten); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	if query == "" {
-		fmt.Fprintf(os.Stderr, "Error: --query parameter is required\n")
+	jsonData, err := loadJSONFile(filename, bigNumbers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
 
-	// Validate JSONPath syntax
-	if err := validateJSONPath(query); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Invalid JSONPath query: %v\n", err)
+	if templateExpr != "" {
+		tmpl, err := template.Parse(templateExpr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing template: %v\n", err)
+			os.Exit(1)
+		}
+
+		output, err := tmpl.Execute(jsonData, allowMissingKeys)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error executing template: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Print(output)
+		return
+	}
+
+	if pointerExpr != "" {
+		ptr, err := pointer.Parse(pointerExpr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		result, err := pointer.Get(jsonData, ptr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := outputResult(result, pretty, raw); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Execute JSONPath query
+	if bigNumbers {
+		if hasBigNumberOrdering(query) {
+			fmt.Fprintf(os.Stderr, "Error: --big-numbers does not support ordering operators (<, >, <=, >=) against a numeric literal, since json.Number compares as a string; rewrite the query to avoid them or drop --big-numbers\n")
+			os.Exit(1)
+		}
+		query = rewriteBigNumberEquality(query)
+	}
+	finalResult, err := runQuery(jsonData, query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Read JSON file
+	// Handle output formatting
+	if err := outputResult(finalResult, pretty, raw); err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadJSONFile opens, reads, and parses filename as JSON. When useNumber is
+// set, numbers are decoded as json.Number instead of float64, preserving
+// the precision of large integer IDs through the query and back out.
+func loadJSONFile(filename string, useNumber bool) (interface{}, error) {
 	file, err := os.Open(filename)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Error opening file: %v", err)
 	}
 	defer file.Close()
 
-	data, err := io.ReadAll(file)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
-		os.Exit(1)
+	decoder := json.NewDecoder(file)
+	if useNumber {
+		decoder.UseNumber()
 	}
 
-	// Parse JSON
 	var jsonData interface{}
-	if err := json.Unmarshal(data, &jsonData); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing JSON: %v\n", err)
-		os.Exit(1)
+	if err := decoder.Decode(&jsonData); err != nil {
+		return nil, fmt.Errorf("Error parsing JSON: %v", err)
 	}
 
-	// Parse JSONPath expression
+	return jsonData, nil
+}
+
+// bigNumberEquality matches a bare numeric literal on the right-hand side
+// of a filter's == or != operator.
+var bigNumberEquality = regexp.MustCompile(`(==|!=)(\s*)(-?\d+(?:\.\d+)?)`)
+
+// bigNumberOrdering matches a bare numeric literal next to an ordering
+// operator (<, >, <=, >=). Quoting it the way rewriteBigNumberEquality does
+// for == and != would not help: json.Number compares as a plain string in
+// ojg's filter evaluator, and lexical string ordering isn't numeric
+// ordering, so the comparison would silently produce wrong matches rather
+// than merely missing ones.
+var bigNumberOrdering = regexp.MustCompile(`(<=|>=|<|>)(\s*)(-?\d+(?:\.\d+)?)`)
+
+// rewriteBigNumberEquality quotes bare numeric literals compared with ==
+// or != so they match json.Number fields, which are themselves plain
+// strings, without round-tripping through float64. Ordering operators
+// (<, >, etc.) are left alone.
+func rewriteBigNumberEquality(query string) string {
+	return bigNumberEquality.ReplaceAllString(query, `$1$2"$3"`)
+}
+
+// hasBigNumberOrdering reports whether query compares a bare numeric literal
+// with an ordering operator, which --big-numbers cannot evaluate correctly.
+func hasBigNumberOrdering(query string) bool {
+	return bigNumberOrdering.MatchString(query)
+}
+
+// runQuery parses and evaluates a JSONPath expression against jsonData. It is
+// the shared query pipeline behind both the default query mode and the
+// `test` subcommand: a single match is returned bare, multiple matches as a
+// slice, and no matches as nil.
+func runQuery(jsonData interface{}, query string) (interface{}, error) {
 	expr, err := jp.ParseString(query)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing JSONPath: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("error parsing JSONPath: %v", err)
 	}
 
-	// Execute JSONPath query
 	result := expr.Get(jsonData)
 	if len(result) == 0 {
-		result = []interface{}{nil}
+		return nil, nil
 	}
-
-	// If single result, unwrap it
-	var finalResult interface{}
 	if len(result) == 1 {
-		finalResult = result[0]
-	} else {
-		finalResult = result
-	}
-
-	// Handle output formatting
-	if err := outputResult(finalResult, pretty, raw); err != nil {
-		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
-		os.Exit(1)
+		return result[0], nil
 	}
+	return result, nil
 }
 
 func outputResult(result interface{}, pretty, raw bool) error {
@@ -122,6 +265,9 @@ func outputResult(result interface{}, pretty, raw bool) error {
 		case string:
 			fmt.Println(v)
 			return nil
+		case json.Number:
+			fmt.Println(v.String())
+			return nil
 		case float64:
 			fmt.Printf("%.10g\n", v)
 			return nil
@@ -131,9 +277,12 @@ func outputResult(result interface{}, pretty, raw bool) error {
 		case []interface{}:
 			// For arrays of simple types, output each on a new line
 			for _, item := range v {
-				if str, ok := item.(string); ok {
-					fmt.Println(str)
-				} else {
+				switch it := item.(type) {
+				case string:
+					fmt.Println(it)
+				case json.Number:
+					fmt.Println(it.String())
+				default:
 					// Fall back to JSON for complex types
 					output, err := json.Marshal(item)
 					if err != nil {
@@ -164,6 +313,17 @@ func outputResult(result interface{}, pretty, raw bool) error {
 	return nil
 }
 
+// effectivePretty resolves the --pretty flag against --ndjson: pretty-printed
+// multi-line records aren't valid NDJSON, so compact output is the default
+// once ndjson mode is active (explicit or inferred from a .ndjson/.jsonl
+// filename), unless the user passed --pretty explicitly.
+func effectivePretty(pretty, prettySet, ndjson bool) bool {
+	if ndjson && !prettySet {
+		return false
+	}
+	return pretty
+}
+
 // Additional helper function to validate JSONPath syntax
 func validateJSONPath(path string) error {
 	// Basic validation - the library will do the real validation
@@ -131,6 +131,34 @@ func TestOutputResult(t *testing.T) {
 			raw:      false,
 			expected: "{\n  \"age\": 30,\n  \"name\": \"Alice\"\n}\n",
 		},
+		{
+			name:     "large json.Number with raw output",
+			input:    json.Number("1234567890123456789"),
+			pretty:   false,
+			raw:      true,
+			expected: "1234567890123456789\n",
+		},
+		{
+			name:     "negative json.Number with raw output",
+			input:    json.Number("-42"),
+			pretty:   false,
+			raw:      true,
+			expected: "-42\n",
+		},
+		{
+			name:     "decimal json.Number with raw output",
+			input:    json.Number("15.99"),
+			pretty:   false,
+			raw:      true,
+			expected: "15.99\n",
+		},
+		{
+			name:     "json.Number with JSON output",
+			input:    json.Number("1234567890123456789"),
+			pretty:   false,
+			raw:      false,
+			expected: "1234567890123456789\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -537,3 +565,156 @@ func executeJSONPathQuery(query string, data interface{}) (interface{}, error) {
 
 	return result, nil
 }
+
+// Test rewriteBigNumberEquality, which quotes bare numeric filter literals
+// so they match json.Number fields under --big-numbers.
+func TestRewriteBigNumberEquality(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "equality",
+			query: "$.items[?(@.id == 1234567890123456789)]",
+			want:  `$.items[?(@.id == "1234567890123456789")]`,
+		},
+		{
+			name:  "inequality",
+			query: "$.items[?(@.id != 42)]",
+			want:  `$.items[?(@.id != "42")]`,
+		},
+		{
+			name:  "decimal",
+			query: "$.items[?(@.price == 15.99)]",
+			want:  `$.items[?(@.price == "15.99")]`,
+		},
+		{
+			name:  "already quoted is left alone",
+			query: `$.items[?(@.id == "42")]`,
+			want:  `$.items[?(@.id == "42")]`,
+		},
+		{
+			name:  "ordering operators are untouched",
+			query: "$.users[?(@.age > 25)]",
+			want:  "$.users[?(@.age > 25)]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rewriteBigNumberEquality(tt.query)
+			if got != tt.want {
+				t.Errorf("rewriteBigNumberEquality(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+// Test that large integer IDs survive a --big-numbers filter query without
+// float64 coercion.
+func TestBigNumberFilterEquality(t *testing.T) {
+	tempFile := createTempJSONFile(t, `{"items": [{"id": 1234567890123456789, "name": "a"}, {"id": 42, "name": "b"}]}`)
+	defer os.Remove(tempFile)
+
+	jsonData, err := loadJSONFile(tempFile, true)
+	if err != nil {
+		t.Fatalf("loadJSONFile failed: %v", err)
+	}
+
+	query := rewriteBigNumberEquality("$.items[?(@.id == 1234567890123456789)]")
+	result, err := runQuery(jsonData, query)
+	if err != nil {
+		t.Fatalf("runQuery failed: %v", err)
+	}
+
+	item, ok := result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a single matching object, got %T", result)
+	}
+	if item["name"] != "a" {
+		t.Errorf("expected item %q, got %v", "a", item["name"])
+	}
+	if id, ok := item["id"].(json.Number); !ok || id.String() != "1234567890123456789" {
+		t.Errorf("expected id to remain an exact json.Number, got %#v", item["id"])
+	}
+}
+
+// Ordering operators compare json.Number as a plain string, which falls
+// through to false against a float64 literal, so --big-numbers must stay an
+// explicit opt-in rather than something --raw turns on implicitly. This
+// guards against that regression: with --big-numbers left off (the default,
+// even under --raw), an ordering filter must still match normally.
+func TestRawModeOrderingFilterNotBroken(t *testing.T) {
+	tempFile := createTempJSONFile(t, `{"products": [{"price": 9}, {"price": 10}, {"price": 100}]}`)
+	defer os.Remove(tempFile)
+
+	jsonData, err := loadJSONFile(tempFile, false)
+	if err != nil {
+		t.Fatalf("loadJSONFile failed: %v", err)
+	}
+
+	result, err := runQuery(jsonData, "$.products[?(@.price > 9)]")
+	if err != nil {
+		t.Fatalf("runQuery failed: %v", err)
+	}
+
+	matches, ok := result.([]interface{})
+	if !ok || len(matches) != 2 {
+		t.Fatalf("expected 2 matching products, got %#v", result)
+	}
+}
+
+// Test hasBigNumberOrdering, which flags queries --big-numbers cannot
+// evaluate correctly: json.Number compares as a string in ojg's filter
+// evaluator, so an ordering operator against a bare numeric literal would
+// otherwise silently return an empty (wrong) result instead of an error.
+func TestHasBigNumberOrdering(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{"greater than", "$.items[?(@.price > 10)]", true},
+		{"less than", "$.items[?(@.price < 10)]", true},
+		{"greater than or equal", "$.items[?(@.price >= 10)]", true},
+		{"less than or equal", "$.items[?(@.price <= 10)]", true},
+		{"equality is unaffected", "$.items[?(@.id == 10)]", false},
+		{"inequality is unaffected", "$.items[?(@.id != 10)]", false},
+		{"no filter at all", "$.items[*].price", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasBigNumberOrdering(tt.query); got != tt.want {
+				t.Errorf("hasBigNumberOrdering(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+// Test effectivePretty, which defaults NDJSON output to compact (one JSON
+// value per line) unless the user explicitly passed --pretty.
+func TestEffectivePretty(t *testing.T) {
+	tests := []struct {
+		name      string
+		pretty    bool
+		prettySet bool
+		ndjson    bool
+		want      bool
+	}{
+		{"ndjson with no explicit flag goes compact", true, false, true, false},
+		{"non-ndjson with no explicit flag is unchanged", true, false, false, true},
+		{"explicit --pretty wins even in ndjson mode", true, true, true, true},
+		{"explicit --pretty=false outside ndjson mode is unchanged", false, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := effectivePretty(tt.pretty, tt.prettySet, tt.ndjson)
+			if got != tt.want {
+				t.Errorf("effectivePretty(%v, %v, %v) = %v, want %v", tt.pretty, tt.prettySet, tt.ndjson, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ohler55/ojg/jp"
+)
+
+// runNDJSONQuery streams newline-delimited JSON from filename (or stdin
+// when filename is "-"), evaluating query against each record
+// independently and writing results to stdout as they are produced,
+// rather than buffering the whole input the way loadJSONFile does.
+func runNDJSONQuery(filename, query string, pretty, raw, filterMode, flatten bool) error {
+	var r io.Reader
+	if filename == "-" {
+		r = os.Stdin
+	} else {
+		file, err := os.Open(filename)
+		if err != nil {
+			return fmt.Errorf("Error opening file: %v", err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	expr, err := jp.ParseString(query)
+	if err != nil {
+		return fmt.Errorf("error parsing JSONPath: %v", err)
+	}
+
+	decoder := json.NewDecoder(r)
+	for {
+		var record interface{}
+		if err := decoder.Decode(&record); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("Error parsing JSON: %v", err)
+		}
+
+		results := expr.Get(record)
+
+		// --filter acts as a grep for JSON logs: emit the whole matching
+		// record rather than the (possibly partial) query result.
+		if filterMode {
+			if len(results) == 0 {
+				continue
+			}
+			if err := outputResult(record, pretty, raw); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result := unwrapResults(results)
+
+		if flatten {
+			if arr, ok := result.([]interface{}); ok {
+				for _, item := range arr {
+					if err := outputResult(item, pretty, raw); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+		}
+
+		if err := outputResult(result, pretty, raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unwrapResults mirrors runQuery's unwrapping of a raw jp.Expr.Get result:
+// a single match is returned bare, multiple matches as a slice, and no
+// matches as nil.
+func unwrapResults(results []interface{}) interface{} {
+	if len(results) == 0 {
+		return nil
+	}
+	if len(results) == 1 {
+		return results[0]
+	}
+	return results
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func writeNDJSONFile(t *testing.T, lines ...string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "records.ndjson")
+
+	content := strings.Join(lines, "\n") + "\n"
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	return tmpFile
+}
+
+func TestRunNDJSONQueryEmitsOneResultPerRecord(t *testing.T) {
+	tempFile := writeNDJSONFile(t,
+		`{"name": "Alice"}`,
+		`{"name": "Bob"}`,
+	)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := runNDJSONQuery(tempFile, "$.name", false, false, false, false); err != nil {
+			t.Fatalf("runNDJSONQuery failed: %v", err)
+		}
+	})
+
+	want := "\"Alice\"\n\"Bob\"\n"
+	if stdout != want {
+		t.Errorf("got %q, want %q", stdout, want)
+	}
+}
+
+func TestRunNDJSONQueryFilter(t *testing.T) {
+	tempFile := writeNDJSONFile(t,
+		`{"level": "info", "msg": "ok"}`,
+		`{"level": "error", "msg": "boom", "error": "disk full"}`,
+	)
+
+	stdout, _ := captureOutput(t, func() {
+		err := runNDJSONQuery(tempFile, "$.error", false, false, true, false)
+		if err != nil {
+			t.Fatalf("runNDJSONQuery failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(stdout, "boom") || strings.Contains(stdout, `"ok"`) {
+		t.Errorf("expected only the error record, got %q", stdout)
+	}
+}
+
+func TestRunNDJSONQueryFlatten(t *testing.T) {
+	tempFile := writeNDJSONFile(t, `{"tags": ["a", "b"]}`)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := runNDJSONQuery(tempFile, "$.tags[*]", false, true, false, true); err != nil {
+			t.Fatalf("runNDJSONQuery failed: %v", err)
+		}
+	})
+
+	want := "a\nb\n"
+	if stdout != want {
+		t.Errorf("got %q, want %q", stdout, want)
+	}
+}
+
+func TestRunNDJSONQueryStdin(t *testing.T) {
+	content := `{"name": "Alice"}` + "\n"
+	r, w, _ := os.Pipe()
+	if _, err := w.WriteString(content); err != nil {
+		t.Fatalf("failed to write to pipe: %v", err)
+	}
+	w.Close()
+
+	origStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = origStdin }()
+
+	stdout, _ := captureOutput(t, func() {
+		if err := runNDJSONQuery("-", "$.name", false, true, false, false); err != nil {
+			t.Fatalf("runNDJSONQuery failed: %v", err)
+		}
+	})
+
+	if stdout != "Alice\n" {
+		t.Errorf("got %q, want %q", stdout, "Alice\n")
+	}
+}
+
+func TestUnwrapResults(t *testing.T) {
+	if got := unwrapResults(nil); got != nil {
+		t.Errorf("expected nil for no results, got %#v", got)
+	}
+	if got := unwrapResults([]interface{}{"a"}); got != "a" {
+		t.Errorf("expected bare single result, got %#v", got)
+	}
+	got := unwrapResults([]interface{}{"a", "b"})
+	if arr, ok := got.([]interface{}); !ok || len(arr) != 2 {
+		t.Errorf("expected a 2-element slice, got %#v", got)
+	}
+}
+
+// BenchmarkRunNDJSONQuery streams a synthetic 100k-line NDJSON file to
+// guard against per-record allocation regressions.
+func BenchmarkRunNDJSONQuery(b *testing.B) {
+	tmpDir := b.TempDir()
+	tmpFile := filepath.Join(tmpDir, "bench.ndjson")
+
+	var sb strings.Builder
+	for i := 0; i < 100000; i++ {
+		sb.WriteString(`{"id": `)
+		sb.WriteString(strconv.Itoa(i))
+		sb.WriteString(`, "name": "user`)
+		sb.WriteString(strconv.Itoa(i))
+		sb.WriteString("\"}\n")
+	}
+	if err := os.WriteFile(tmpFile, []byte(sb.String()), 0644); err != nil {
+		b.Fatalf("failed to write benchmark file: %v", err)
+	}
+
+	oldStdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatalf("failed to open %s: %v", os.DevNull, err)
+	}
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := runNDJSONQuery(tmpFile, "$.name", false, true, false, false); err != nil {
+			b.Fatalf("runNDJSONQuery failed: %v", err)
+		}
+	}
+}
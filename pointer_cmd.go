@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/berrydev-ai/query_json/internal/pointer"
+)
+
+// runPointerCommand implements the get, set and delete subcommands, which
+// address a single location in a JSON document via an RFC 6901 JSON
+// Pointer instead of a JSONPath query.
+func runPointerCommand(cmd string, args []string) int {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	ptrFlag := fs.String("pointer", "", "RFC 6901 JSON Pointer (e.g. /users/0/name)")
+	valueFlag := fs.String("value", "", "JSON value to write (set only)")
+	force := fs.Bool("force", false, "Create missing intermediate objects/arrays (set only)")
+	pretty := fs.Bool("pretty", true, "Pretty print JSON output")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s %s --pointer <pointer> [options] <json-file>\n", os.Args[0], cmd)
+		return 1
+	}
+	filename := fs.Arg(0)
+
+	ptr, err := pointer.Parse(*ptrFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	doc, err := readPointerDoc(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	switch cmd {
+	case "get":
+		result, err := pointer.Get(doc, ptr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if err := outputResult(result, *pretty, false); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "set":
+		if *valueFlag == "" {
+			fmt.Fprintf(os.Stderr, "Error: --value is required for set\n")
+			return 1
+		}
+		var value interface{}
+		valueDecoder := json.NewDecoder(strings.NewReader(*valueFlag))
+		valueDecoder.UseNumber()
+		if err := valueDecoder.Decode(&value); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --value JSON: %v\n", err)
+			return 1
+		}
+		updated, err := pointer.Set(doc, ptr, value, *force)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return writePointerDoc(filename, updated, *pretty)
+
+	case "delete":
+		updated, err := pointer.Delete(doc, ptr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return writePointerDoc(filename, updated, *pretty)
+
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown pointer command: %s\n", cmd)
+		return 1
+	}
+}
+
+// readPointerDoc reads and parses the JSON document a pointer subcommand
+// operates on, from filename or, when filename is "-", from stdin. Numbers
+// are decoded as json.Number rather than float64, so set and delete can
+// write the document back out without corrupting large integers elsewhere
+// in it.
+func readPointerDoc(filename string) (interface{}, error) {
+	var r io.Reader
+	if filename == "-" {
+		r = os.Stdin
+	} else {
+		file, err := os.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading input: %v", err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("Error parsing JSON: %v", err)
+	}
+	return doc, nil
+}
+
+// writePointerDoc re-serializes doc back to filename, or to stdout when
+// filename is "-", and returns the process exit code.
+func writePointerDoc(filename string, doc interface{}, pretty bool) int {
+	var output []byte
+	var err error
+	if pretty {
+		output, err = json.MarshalIndent(doc, "", "  ")
+	} else {
+		output, err = json.Marshal(doc)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+		return 1
+	}
+	output = append(output, '\n')
+
+	if filename == "-" {
+		if _, err := os.Stdout.Write(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if err := os.WriteFile(filename, output, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/berrydev-ai/query_json/internal/pointer"
+)
+
+func writePointerTestFile(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	return path
+}
+
+func TestRunPointerCommandGet(t *testing.T) {
+	path := writePointerTestFile(t, `{"users": [{"name": "Alice"}, {"name": "Bob"}]}`)
+
+	stdout, _ := captureOutput(t, func() {
+		if code := runPointerCommand("get", []string{"--pointer", "/users/1/name", path}); code != 0 {
+			t.Fatalf("expected exit code 0, got %d", code)
+		}
+	})
+
+	if strings.TrimSpace(stdout) != `"Bob"` {
+		t.Errorf("got %q, want %q", stdout, `"Bob"`)
+	}
+}
+
+func TestRunPointerCommandSet(t *testing.T) {
+	path := writePointerTestFile(t, `{"name": "Alice"}`)
+
+	if code := runPointerCommand("set", []string{"--pointer", "/name", "--value", `"Bob"`, path}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("failed to parse written file: %v", err)
+	}
+	if doc["name"] != "Bob" {
+		t.Errorf("expected name to be Bob, got %v", doc["name"])
+	}
+}
+
+func TestRunPointerCommandSetForce(t *testing.T) {
+	path := writePointerTestFile(t, `{}`)
+
+	if code := runPointerCommand("set", []string{"--pointer", "/a/b/0/c", "--value", `"v"`, "--force", path}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("failed to parse written file: %v", err)
+	}
+	ptr, _ := pointer.Parse("/a/b/0/c")
+	got, err := pointer.Get(doc, ptr)
+	if err != nil || got != "v" {
+		t.Errorf("expected value %q at forced path, got %v (err %v)", "v", got, err)
+	}
+}
+
+func TestRunPointerCommandSetAppend(t *testing.T) {
+	path := writePointerTestFile(t, `{"items": [1, 2]}`)
+
+	if code := runPointerCommand("set", []string{"--pointer", "/items/-", "--value", "3", path}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("failed to parse written file: %v", err)
+	}
+	items, ok := doc["items"].([]interface{})
+	if !ok || len(items) != 3 || items[2] != float64(3) {
+		t.Errorf("expected items to be appended to, got %#v", doc["items"])
+	}
+}
+
+func TestRunPointerCommandDelete(t *testing.T) {
+	path := writePointerTestFile(t, `{"users": [{"name": "Alice"}, {"name": "Bob"}]}`)
+
+	if code := runPointerCommand("delete", []string{"--pointer", "/users/0", path}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("failed to parse written file: %v", err)
+	}
+	users, ok := doc["users"].([]interface{})
+	if !ok || len(users) != 1 {
+		t.Fatalf("expected 1 user remaining, got %#v", doc["users"])
+	}
+	if users[0].(map[string]interface{})["name"] != "Bob" {
+		t.Errorf("expected Bob to remain, got %#v", users[0])
+	}
+}
+
+func TestRunPointerCommandStdinStdout(t *testing.T) {
+	content := `{"name": "Alice"}` + "\n"
+	r, w, _ := os.Pipe()
+	if _, err := w.WriteString(content); err != nil {
+		t.Fatalf("failed to write to pipe: %v", err)
+	}
+	w.Close()
+
+	origStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = origStdin }()
+
+	stdout, _ := captureOutput(t, func() {
+		if code := runPointerCommand("set", []string{"--pointer", "/name", "--value", `"Bob"`, "-"}); code != 0 {
+			t.Fatalf("expected exit code 0, got %d", code)
+		}
+	})
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &doc); err != nil {
+		t.Fatalf("failed to parse stdout as JSON: %v", err)
+	}
+	if doc["name"] != "Bob" {
+		t.Errorf("expected name to be Bob, got %v", doc["name"])
+	}
+}
+
+// TestRunPointerCommandSetPreservesBigIntegers guards against set
+// corrupting an unrelated large integer elsewhere in the document by
+// round-tripping it through float64 on write.
+func TestRunPointerCommandSetPreservesBigIntegers(t *testing.T) {
+	path := writePointerTestFile(t, `{"id": 1234567890123456789, "name": "Alice"}`)
+
+	if code := runPointerCommand("set", []string{"--pointer", "/name", "--value", `"Bob"`, path}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(data), "1234567890123456789") {
+		t.Errorf("expected id to keep its exact precision, got %s", data)
+	}
+}
+
+// TestRunPointerCommandDeletePreservesBigIntegers is the delete-path
+// counterpart of TestRunPointerCommandSetPreservesBigIntegers.
+func TestRunPointerCommandDeletePreservesBigIntegers(t *testing.T) {
+	path := writePointerTestFile(t, `{"id": 1234567890123456789, "name": "Alice", "tag": "x"}`)
+
+	if code := runPointerCommand("delete", []string{"--pointer", "/tag", path}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(data), "1234567890123456789") {
+		t.Errorf("expected id to keep its exact precision, got %s", data)
+	}
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// testFile is the declarative shape of a single JSON test-suite file consumed
+// by the `test` subcommand: a target document (inline or via path) plus one
+// or more steps to run against it.
+type testFile struct {
+	Name  string          `json:"name"`
+	File  string          `json:"file,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Steps []testStep      `json:"steps"`
+}
+
+// testStep is a single JSONPath query and its expectation within a testFile.
+// At least one of Expected, ExpectedContains, ExpectedCount or ExpectedType
+// should be set.
+type testStep struct {
+	Query            string      `json:"query"`
+	Expected         interface{} `json:"expected,omitempty"`
+	HasExpected      bool        `json:"-"`
+	ExpectedContains interface{} `json:"expectedContains,omitempty"`
+	ExpectedCount    *int        `json:"expectedCount,omitempty"`
+	ExpectedType     string      `json:"expectedType,omitempty"`
+}
+
+// UnmarshalJSON tracks whether "expected" was present in the source JSON,
+// so a step can assert the result is JSON null ("expected": null) instead of
+// that being indistinguishable from no expectation being set at all.
+func (s *testStep) UnmarshalJSON(data []byte) error {
+	type alias testStep
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	*s = testStep(a)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	_, s.HasExpected = raw["expected"]
+	return nil
+}
+
+// runTestCommand implements the `test` subcommand: it loads one JSON test
+// file or every *.json file beneath a directory, runs each declared step
+// through runQuery, and prints a pass/fail summary. It returns the process
+// exit code.
+func runTestCommand(args []string) int {
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s test <file-or-directory>\n", os.Args[0])
+		return 1
+	}
+
+	files, err := collectTestFiles(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	total := 0
+	failed := 0
+
+	for _, path := range files {
+		tf, err := loadTestFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			total++
+			failed++
+			continue
+		}
+		if len(tf.Steps) == 0 {
+			// Not every *.json file under a directory is a test file; one
+			// with no steps is treated as a fixture referenced by others.
+			continue
+		}
+
+		target, err := tf.target(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			total++
+			failed++
+			continue
+		}
+
+		for i, step := range tf.Steps {
+			total++
+			if err := step.run(target); err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "%s: step %d (%s): %v\n", path, i+1, step.Query, err)
+			}
+		}
+	}
+
+	if failed == 0 {
+		fmt.Printf("%d tests completed successfully\n", total)
+		return 0
+	}
+
+	fmt.Printf("Failed %d of %d tests\n", failed, total)
+	return 1
+}
+
+// collectTestFiles returns path itself if it names a file, or the sorted
+// list of every *.json file beneath it if it names a directory.
+func collectTestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(p, ".json") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// loadTestFile reads and parses a single declarative test file.
+func loadTestFile(path string) (*testFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tf testFile
+	if err := json.Unmarshal(data, &tf); err != nil {
+		return nil, fmt.Errorf("invalid test file: %v", err)
+	}
+
+	return &tf, nil
+}
+
+// target resolves the JSON document a testFile's steps run against: inline
+// data takes precedence over file, which is read relative to the test
+// file's own directory.
+func (tf *testFile) target(testPath string) (interface{}, error) {
+	var data []byte
+
+	switch {
+	case len(tf.Data) > 0:
+		data = tf.Data
+	case tf.File != "":
+		targetPath := tf.File
+		if !filepath.IsAbs(targetPath) {
+			targetPath = filepath.Join(filepath.Dir(testPath), targetPath)
+		}
+		b, err := os.ReadFile(targetPath)
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	default:
+		return nil, fmt.Errorf(`test file declares neither "data" nor "file"`)
+	}
+
+	var target interface{}
+	if err := json.Unmarshal(data, &target); err != nil {
+		return nil, fmt.Errorf("invalid target JSON: %v", err)
+	}
+	return target, nil
+}
+
+// run executes the step's query against target and checks the result
+// against whichever expectation fields are set.
+func (s testStep) run(target interface{}) error {
+	actual, err := runQuery(target, s.Query)
+	if err != nil {
+		return err
+	}
+
+	if s.ExpectedCount != nil {
+		count := 0
+		if arr, ok := actual.([]interface{}); ok {
+			count = len(arr)
+		} else if actual != nil {
+			count = 1
+		}
+		if count != *s.ExpectedCount {
+			return fmt.Errorf("expected %d results, got %d", *s.ExpectedCount, count)
+		}
+	}
+
+	if s.ExpectedType != "" {
+		if actualType := jsonType(actual); actualType != s.ExpectedType {
+			return fmt.Errorf("expected type %q, got %q", s.ExpectedType, actualType)
+		}
+	}
+
+	if s.ExpectedContains != nil && !containsValue(actual, s.ExpectedContains) {
+		want, _ := json.Marshal(s.ExpectedContains)
+		return fmt.Errorf("expected result to contain %s", want)
+	}
+
+	if s.HasExpected {
+		want, err := canonicalize(s.Expected)
+		if err != nil {
+			return err
+		}
+		got, err := canonicalize(actual)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(got, want) {
+			wantJSON, _ := json.Marshal(want)
+			gotJSON, _ := json.Marshal(got)
+			return fmt.Errorf("expected %s, got %s", wantJSON, gotJSON)
+		}
+	}
+
+	return nil
+}
+
+// canonicalize round-trips v through json.Marshal/Unmarshal so values coming
+// from Go literals (e.g. int) compare equal to values decoded from JSON
+// (float64) via reflect.DeepEqual.
+func canonicalize(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// jsonType reports the JSON type name of v, for use with expectedType.
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64, json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// containsValue reports whether actual contains want: for array results,
+// whether any element canonically equals want; for string results, whether
+// want is a substring; otherwise whether actual itself equals want.
+func containsValue(actual, want interface{}) bool {
+	if arr, ok := actual.([]interface{}); ok {
+		for _, item := range arr {
+			if valuesEqual(item, want) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if s, ok := actual.(string); ok {
+		if ws, ok := want.(string); ok {
+			return strings.Contains(s, ws)
+		}
+	}
+
+	return valuesEqual(actual, want)
+}
+
+func valuesEqual(a, b interface{}) bool {
+	ca, err := canonicalize(a)
+	if err != nil {
+		return false
+	}
+	cb, err := canonicalize(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(ca, cb)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// captureOutput redirects stdout and stderr for the duration of fn and
+// returns everything written to each.
+func captureOutput(t *testing.T, fn func()) (stdout, stderr string) {
+	t.Helper()
+
+	origOut, origErr := os.Stdout, os.Stderr
+	outR, outW, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = outW, errW
+
+	fn()
+
+	outW.Close()
+	errW.Close()
+	os.Stdout, os.Stderr = origOut, origErr
+
+	outBytes, _ := io.ReadAll(outR)
+	errBytes, _ := io.ReadAll(errR)
+	return string(outBytes), string(errBytes)
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestRunTestCommandSuccess(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data.json", testJSON)
+	writeTestFile(t, dir, "suite.json", `{
+		"name": "users",
+		"file": "data.json",
+		"steps": [
+			{"query": "$.users[0].name", "expected": "Alice"},
+			{"query": "$.users", "expectedCount": 3},
+			{"query": "$.company.name", "expectedType": "string"},
+			{"query": "$.users[*].name", "expectedContains": "Bob"}
+		]
+	}`)
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = runTestCommand([]string{dir})
+	})
+
+	if code != 0 {
+		t.Errorf("expected exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	if stdout != "4 tests completed successfully\n" {
+		t.Errorf("unexpected stdout: %q", stdout)
+	}
+}
+
+func TestRunTestCommandFailure(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "suite.json", `{
+		"name": "bad",
+		"data": {"value": 1},
+		"steps": [
+			{"query": "$.value", "expected": 2}
+		]
+	}`)
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = runTestCommand([]string{dir})
+	})
+
+	if code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if stdout != "Failed 1 of 1 tests\n" {
+		t.Errorf("unexpected stdout: %q", stdout)
+	}
+	if stderr == "" {
+		t.Errorf("expected failure detail on stderr")
+	}
+}
+
+func TestRunTestCommandSingleFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "suite.json", `{
+		"name": "inline",
+		"data": [1, 2, 3],
+		"steps": [{"query": "$[1]", "expected": 2}]
+	}`)
+
+	var code int
+	captureOutput(t, func() {
+		code = runTestCommand([]string{path})
+	})
+
+	if code != 0 {
+		t.Errorf("expected exit code 0, got %d", code)
+	}
+}
+
+func TestTestStepRun(t *testing.T) {
+	target := map[string]interface{}{"name": "Alice", "age": 30.0}
+
+	tests := []struct {
+		name    string
+		step    testStep
+		wantErr bool
+	}{
+		{"expected match", testStep{Query: "$.name", Expected: "Alice", HasExpected: true}, false},
+		{"expected mismatch", testStep{Query: "$.name", Expected: "Bob", HasExpected: true}, true},
+		{"expectedType match", testStep{Query: "$.age", ExpectedType: "number"}, false},
+		{"expectedType mismatch", testStep{Query: "$.age", ExpectedType: "string"}, true},
+		{"expected null matches a null result", testStep{Query: "$.missing", HasExpected: true}, false},
+		{"no expectation set skips the check even on a mismatching query", testStep{Query: "$.name"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.step.run(target)
+			if tt.wantErr && err == nil {
+				t.Errorf("expected an error, got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestTestStepUnmarshalExpectedNull verifies "expected": null is parsed as
+// an explicit expectation (HasExpected true), distinct from the field being
+// absent entirely, so a step can assert a query resolves to JSON null.
+func TestTestStepUnmarshalExpectedNull(t *testing.T) {
+	var withNull testStep
+	if err := json.Unmarshal([]byte(`{"query": "$.missing", "expected": null}`), &withNull); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !withNull.HasExpected {
+		t.Errorf("expected HasExpected to be true when \"expected\": null is present")
+	}
+
+	var withoutExpected testStep
+	if err := json.Unmarshal([]byte(`{"query": "$.missing"}`), &withoutExpected); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if withoutExpected.HasExpected {
+		t.Errorf("expected HasExpected to be false when \"expected\" is absent")
+	}
+}
+
+// TestRunTestCommandExpectedNull is the end-to-end counterpart: a step that
+// asserts a query resolves to JSON null must actually run the check, not
+// silently pass the way an unset Expected would.
+func TestRunTestCommandExpectedNull(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "suite.json", `{
+		"name": "null-expectation",
+		"data": {"value": 1},
+		"steps": [
+			{"query": "$.missing", "expected": null},
+			{"query": "$.value", "expected": null}
+		]
+	}`)
+
+	var code int
+	stdout, _ := captureOutput(t, func() {
+		code = runTestCommand([]string{dir})
+	})
+
+	if code != 1 {
+		t.Errorf("expected exit code 1 (one passing null check, one failing), got %d", code)
+	}
+	if stdout != "Failed 1 of 2 tests\n" {
+		t.Errorf("unexpected stdout: %q", stdout)
+	}
+}